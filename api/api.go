@@ -0,0 +1,284 @@
+// Package api implements an optional HTTP control surface for deej. When
+// enabled, it lets external tools - phone shortcuts, Stream Deck plugins,
+// home-automation systems - read and manipulate slider state without going
+// through the serial connection, and stream live slider events to clients
+// that want to render their own meters.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SliderState is a single slider's last known value, exposed over the API.
+type SliderState struct {
+	SliderID int      `json:"sliderId"`
+	Value    float32  `json:"value"`
+	Sessions []string `json:"sessions"`
+}
+
+// StateProvider is implemented by deej.Deej and gives the API server access
+// to live slider/session state and config reload, without the API package
+// importing the root deej package (which would create an import cycle).
+type StateProvider interface {
+
+	// Sliders returns a snapshot of all known sliders and their current values.
+	Sliders() []SliderState
+
+	// SetSliderOverride sets a session's volume on a slider regardless of the
+	// slider's physical position. The volume is applied once and is not kept
+	// as a standing override, so a later change to that session replaces it.
+	SetSliderOverride(sliderID int, sessionName string, volume float32) error
+
+	// SetSliderMute toggles mute for a specific session bound to a slider.
+	SetSliderMute(sliderID int, sessionName string, muted bool) error
+
+	// ReloadConfig forces a reload of the on-disk config, as if it had changed.
+	ReloadConfig() error
+}
+
+// Options configures the HTTP API server.
+type Options struct {
+
+	// Address is the host:port the server listens on, e.g. "127.0.0.1:9656".
+	Address string
+
+	// AuthToken, when non-empty, must be supplied by clients as a bearer
+	// token on every request.
+	AuthToken string
+
+	// TLSCertFile and TLSKeyFile, when both set, make the server listen with TLS.
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// Server exposes deej's slider/session state over HTTP and streams live
+// slider events to subscribed clients via Server-Sent Events.
+type Server struct {
+	logger  *zap.SugaredLogger
+	options Options
+	state   StateProvider
+
+	listener   net.Listener
+	httpServer *http.Server
+
+	subscribersMutex sync.Mutex
+	subscribers      map[chan SliderState]bool
+}
+
+// NewServer creates an HTTP API server bound to the given state provider.
+// Call Start to begin serving.
+func NewServer(logger *zap.SugaredLogger, options Options, state StateProvider) *Server {
+	return &Server{
+		logger:      logger.Named("api"),
+		options:     options,
+		state:       state,
+		subscribers: make(map[chan SliderState]bool),
+	}
+}
+
+// Start begins serving the HTTP API in the background and returns once the
+// listener is ready to accept connections.
+func (s *Server) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sliders", s.requireAuth(s.handleSliders))
+	mux.HandleFunc("/sliders/override", s.requireAuth(s.handleOverride))
+	mux.HandleFunc("/sliders/mute", s.requireAuth(s.handleMute))
+	mux.HandleFunc("/config/reload", s.requireAuth(s.handleReload))
+	mux.HandleFunc("/events", s.requireAuth(s.handleEvents))
+
+	listener, err := net.Listen("tcp", s.options.Address)
+	if err != nil {
+		return fmt.Errorf("listen on %q: %w", s.options.Address, err)
+	}
+
+	s.listener = listener
+	s.httpServer = &http.Server{Handler: mux}
+
+	go func() {
+		var serveErr error
+		if s.options.TLSCertFile != "" && s.options.TLSKeyFile != "" {
+			serveErr = s.httpServer.ServeTLS(listener, s.options.TLSCertFile, s.options.TLSKeyFile)
+		} else {
+			serveErr = s.httpServer.Serve(listener)
+		}
+
+		if serveErr != nil && serveErr != http.ErrServerClosed {
+			s.logger.Warnw("HTTP API server stopped unexpectedly", "error", serveErr)
+		}
+	}()
+
+	s.logger.Infow("Started HTTP API server", "address", s.options.Address)
+
+	return nil
+}
+
+// Stop shuts the HTTP API server down, closing any open event streams.
+func (s *Server) Stop() {
+	if s.httpServer == nil {
+		return
+	}
+
+	s.logger.Debug("Stopping HTTP API server")
+
+	s.subscribersMutex.Lock()
+	for ch := range s.subscribers {
+		close(ch)
+		delete(s.subscribers, ch)
+	}
+	s.subscribersMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.logger.Warnw("Failed to gracefully shut down HTTP API server", "error", err)
+	}
+}
+
+// PublishSliderEvent fans a slider update out to every connected SSE client.
+// Deej calls this whenever a slider's value or bound sessions change, which
+// in turn happens when the session mapper resolves a new binding or a
+// backend reports a volume/mute change.
+func (s *Server) PublishSliderEvent(state SliderState) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- state:
+		default:
+			// slow consumer, drop the event rather than block the publisher
+		}
+	}
+}
+
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.options.AuthToken != "" {
+			token := r.Header.Get("Authorization")
+			expected := "Bearer " + s.options.AuthToken
+
+			if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) handleSliders(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.state.Sliders())
+}
+
+func (s *Server) handleOverride(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SliderID int     `json:"sliderId"`
+		Session  string  `json:"session"`
+		Volume   float32 `json:"volume"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decode request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.state.SetSliderOverride(req.SliderID, req.Session, req.Volume); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleMute(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SliderID int    `json:"sliderId"`
+		Session  string `json:"session"`
+		Muted    bool   `json:"muted"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decode request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.state.SetSliderMute(req.SliderID, req.Session, req.Muted); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if err := s.state.ReloadConfig(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleEvents streams slider updates to the client as Server-Sent Events
+// until the request's context is cancelled (the client disconnects) or the
+// server is stopped.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan SliderState, 16)
+
+	s.subscribersMutex.Lock()
+	s.subscribers[ch] = true
+	s.subscribersMutex.Unlock()
+
+	defer func() {
+		s.subscribersMutex.Lock()
+		delete(s.subscribers, ch)
+		s.subscribersMutex.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	for {
+		select {
+		case state, open := <-ch:
+			if !open {
+				return
+			}
+
+			payload, err := json.Marshal(state)
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
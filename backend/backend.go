@@ -0,0 +1,104 @@
+// Package backend abstracts over the different ways deej can find an
+// application's audio session and change its volume. Each operating system -
+// and, on Linux, each sound server or player - exposes a different API for
+// this, so every concrete implementation is kept behind the SessionBackend
+// interface and selected by name from config.
+package backend
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Session identifies a single controllable audio session as seen by a
+// backend: an application, a device, or a remote endpoint like an MPV
+// instance.
+type Session struct {
+
+	// Key uniquely identifies the session within its backend, e.g. a process
+	// name ("spotify.exe"), a PulseAudio sink-input index, or an MPV socket path.
+	Key string
+
+	// DisplayName is a human-readable label suitable for logs, the tray, or the
+	// on-device display.
+	DisplayName string
+}
+
+// VolumeEvent is delivered by a backend's Subscribe channel whenever a
+// session's volume or mute state changes outside of deej (e.g. the user
+// changed it from the app itself, or the session appeared/disappeared).
+type VolumeEvent struct {
+	Session Session
+	Volume  float32
+	Muted   bool
+	Removed bool
+}
+
+// SessionBackend is implemented by every sound-system integration deej knows
+// how to drive. A single running deej instance may have several backends
+// active at once (e.g. PulseAudio for local apps and MPV for a remote player),
+// with sliders routed to one or more of them by config.
+type SessionBackend interface {
+
+	// Name identifies the backend for config (the `backend:` list) and logging.
+	Name() string
+
+	// Initialize prepares the backend for use - connecting to a daemon,
+	// opening a socket, and the like. It's called once before first use.
+	Initialize(logger *zap.SugaredLogger) error
+
+	// Enumerate lists the sessions currently known to this backend.
+	Enumerate() ([]Session, error)
+
+	// Bind resolves a config-provided process/session name to a concrete
+	// Session the backend can act on, or an error if none matches.
+	Bind(name string) (Session, error)
+
+	// SetVolume sets a session's volume, in the canonical deej range of 0.0 to 1.0.
+	SetVolume(session Session, volume float32) error
+
+	// SetMute mutes or unmutes a session.
+	SetMute(session Session, muted bool) error
+
+	// Subscribe returns a channel of VolumeEvent that the backend publishes to
+	// whenever session state changes outside of deej's own calls. Subscribe
+	// may be called more than once; every call returns the same channel.
+	Subscribe() (<-chan VolumeEvent, error)
+
+	// Shutdown releases any resources acquired by Initialize.
+	Shutdown() error
+}
+
+// registry holds every backend implementation registered at init time,
+// keyed by the name used in config.
+var registry = map[string]func() SessionBackend{}
+
+// Register makes a backend implementation available under the given name for
+// later lookup via Get. It's meant to be called from the init() function of
+// each backend implementation file.
+func Register(name string, factory func() SessionBackend) {
+	registry[name] = factory
+}
+
+// Get constructs a fresh instance of the named backend, or returns an error
+// if no backend is registered under that name.
+func Get(name string) (SessionBackend, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("no session backend registered under name %q", name)
+	}
+
+	return factory(), nil
+}
+
+// Names returns the names of every registered backend, primarily for
+// diagnostics and config validation error messages.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	return names
+}
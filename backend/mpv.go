@@ -0,0 +1,208 @@
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+func init() {
+	Register("mpv", func() SessionBackend { return &mpvBackend{} })
+}
+
+// mpvVolumePropertyID and mpvMutePropertyID are the observe_property IDs
+// Bind registers, matched back up against property-change events by name
+// (not by ID) in watchEvents.
+const (
+	mpvVolumePropertyID = 1
+	mpvMutePropertyID   = 2
+)
+
+// mpvCommand is a single JSON IPC request as documented by MPV's
+// `input-ipc-server` protocol.
+type mpvCommand struct {
+	Command   []interface{} `json:"command"`
+	RequestID int64         `json:"request_id"`
+}
+
+type mpvResponse struct {
+	Error     string      `json:"error"`
+	Data      interface{} `json:"data"`
+	RequestID int64       `json:"request_id"`
+	Event     string      `json:"event"`
+	Name      string      `json:"name"`
+}
+
+// mpvBackend controls a single remote MPV instance over its JSON IPC socket.
+// Every slider bound to this backend is a config-level alias for the same
+// MPV instance; "sessions" here just distinguish volume from mute control if
+// a future version wants to expose more than one property per socket.
+type mpvBackend struct {
+	logger *zap.SugaredLogger
+
+	socketPath string
+	conn       net.Conn
+	connMutex  sync.Mutex
+
+	requestCounter int64
+
+	events chan VolumeEvent
+}
+
+func (m *mpvBackend) Name() string {
+	return "mpv"
+}
+
+func (m *mpvBackend) Initialize(logger *zap.SugaredLogger) error {
+	m.logger = logger.Named("mpv")
+	m.events = make(chan VolumeEvent, 16)
+
+	return nil
+}
+
+// Bind connects to the MPV IPC socket at the given path. The socket path
+// doubles as the session key and display name, since a single MPV instance
+// doesn't expose a list of sub-sessions.
+func (m *mpvBackend) Bind(socketPath string) (Session, error) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return Session{}, fmt.Errorf("connect to mpv ipc socket %q: %w", socketPath, err)
+	}
+
+	m.connMutex.Lock()
+	m.socketPath = socketPath
+	m.conn = conn
+	m.connMutex.Unlock()
+
+	go m.watchEvents(conn)
+
+	// Ask mpv to actually emit "property-change" events for the properties
+	// watchEvents listens for; mpv stays silent about both until told to
+	// observe them.
+	if err := m.sendCommand("observe_property", mpvVolumePropertyID, "volume"); err != nil {
+		m.logger.Warnw("Failed to observe mpv volume property", "error", err)
+	}
+
+	if err := m.sendCommand("observe_property", mpvMutePropertyID, "mute"); err != nil {
+		m.logger.Warnw("Failed to observe mpv mute property", "error", err)
+	}
+
+	return Session{Key: socketPath, DisplayName: socketPath}, nil
+}
+
+func (m *mpvBackend) Enumerate() ([]Session, error) {
+	if m.conn == nil {
+		return nil, nil
+	}
+
+	return []Session{{Key: m.socketPath, DisplayName: m.socketPath}}, nil
+}
+
+func (m *mpvBackend) SetVolume(session Session, volume float32) error {
+	// MPV's own volume property is 0-100, while deej's canonical range is 0.0-1.0
+	return m.sendCommand("set_property", "volume", volume*100)
+}
+
+func (m *mpvBackend) SetMute(session Session, muted bool) error {
+	return m.sendCommand("set_property", "mute", muted)
+}
+
+func (m *mpvBackend) Subscribe() (<-chan VolumeEvent, error) {
+	return m.events, nil
+}
+
+// Shutdown closes the IPC socket, which ends watchEvents' scanner loop;
+// watchEvents closes m.events itself once it has actually stopped producing,
+// rather than racing a send against a close here.
+func (m *mpvBackend) Shutdown() error {
+	m.connMutex.Lock()
+	conn := m.conn
+	m.connMutex.Unlock()
+
+	if conn == nil {
+		close(m.events)
+		return nil
+	}
+
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("close mpv ipc socket: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mpvBackend) sendCommand(args ...interface{}) error {
+	m.connMutex.Lock()
+	conn := m.conn
+	m.connMutex.Unlock()
+
+	if conn == nil {
+		return fmt.Errorf("mpv backend is not bound to a socket yet")
+	}
+
+	cmd := mpvCommand{
+		Command:   args,
+		RequestID: atomic.AddInt64(&m.requestCounter, 1),
+	}
+
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		return fmt.Errorf("marshal mpv command: %w", err)
+	}
+
+	if _, err := conn.Write(append(payload, '\n')); err != nil {
+		return fmt.Errorf("write mpv command: %w", err)
+	}
+
+	return nil
+}
+
+// watchEvents reads newline-delimited JSON from the IPC socket and forwards
+// mpv's own property-change notifications (for "volume" and "mute") as
+// VolumeEvents, so externally-driven changes (e.g. the user adjusting mpv's
+// own UI) get reflected back into deej.
+func (m *mpvBackend) watchEvents(conn net.Conn) {
+	defer close(m.events)
+
+	scanner := bufio.NewScanner(conn)
+
+	// mpv reports volume and mute as separate property changes; keep the last
+	// known value of each so every event carries both.
+	var volume float32
+	var muted bool
+
+	for scanner.Scan() {
+		var resp mpvResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			continue
+		}
+
+		if resp.Event != "property-change" {
+			continue
+		}
+
+		switch resp.Name {
+		case "volume":
+			if v, ok := resp.Data.(float64); ok {
+				volume = float32(v) / 100
+			}
+		case "mute":
+			if v, ok := resp.Data.(bool); ok {
+				muted = v
+			}
+		default:
+			continue
+		}
+
+		m.events <- VolumeEvent{
+			Session: Session{Key: m.socketPath, DisplayName: m.socketPath},
+			Volume:  volume,
+			Muted:   muted,
+		}
+	}
+}
@@ -0,0 +1,280 @@
+//go:build linux
+
+package backend
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+func init() {
+	Register("pulseaudio", func() SessionBackend { return &pulseAudioBackend{} })
+}
+
+// pulseAudioBackend drives PulseAudio and PipeWire's pulse-compatible
+// sinks/sink-inputs via the `pactl` CLI, which both projects ship. It binds
+// config session names against a sink-input's "application.name" property.
+type pulseAudioBackend struct {
+	logger *zap.SugaredLogger
+
+	subscribeOnce sync.Once
+	events        chan VolumeEvent
+
+	subscribeMutex sync.Mutex
+	subscribeCmd   *exec.Cmd
+}
+
+func (p *pulseAudioBackend) Name() string {
+	return "pulseaudio"
+}
+
+func (p *pulseAudioBackend) Initialize(logger *zap.SugaredLogger) error {
+	p.logger = logger.Named("pulseaudio")
+	p.events = make(chan VolumeEvent, 16)
+
+	if _, err := exec.LookPath("pactl"); err != nil {
+		return fmt.Errorf("pactl not found on PATH: %w", err)
+	}
+
+	return nil
+}
+
+func (p *pulseAudioBackend) Enumerate() ([]Session, error) {
+	infos, err := p.enumerateInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, len(infos))
+	for i, info := range infos {
+		sessions[i] = info.Session
+	}
+
+	return sessions, nil
+}
+
+// enumerateInfo is like Enumerate but keeps each sink-input's volume and mute
+// state around, for watchEvents to report on an external change.
+func (p *pulseAudioBackend) enumerateInfo() ([]sinkInputInfo, error) {
+	out, err := exec.Command("pactl", "list", "sink-inputs").Output()
+	if err != nil {
+		return nil, fmt.Errorf("list sink-inputs: %w", err)
+	}
+
+	return parseSinkInputs(out), nil
+}
+
+func (p *pulseAudioBackend) Bind(name string) (Session, error) {
+	sessions, err := p.Enumerate()
+	if err != nil {
+		return Session{}, err
+	}
+
+	for _, s := range sessions {
+		if strings.EqualFold(s.DisplayName, name) {
+			return s, nil
+		}
+	}
+
+	return Session{}, fmt.Errorf("no pulseaudio sink-input bound to name %q", name)
+}
+
+func (p *pulseAudioBackend) SetVolume(session Session, volume float32) error {
+	percent := fmt.Sprintf("%d%%", int(volume*100))
+
+	if err := exec.Command("pactl", "set-sink-input-volume", session.Key, percent).Run(); err != nil {
+		return fmt.Errorf("set volume for sink-input %s: %w", session.Key, err)
+	}
+
+	return nil
+}
+
+func (p *pulseAudioBackend) SetMute(session Session, muted bool) error {
+	flag := "0"
+	if muted {
+		flag = "1"
+	}
+
+	if err := exec.Command("pactl", "set-sink-input-mute", session.Key, flag).Run(); err != nil {
+		return fmt.Errorf("set mute for sink-input %s: %w", session.Key, err)
+	}
+
+	return nil
+}
+
+// Subscribe starts `pactl subscribe` in the background on first call and
+// translates its change events into VolumeEvent. Every caller shares the same
+// underlying subscription and channel.
+func (p *pulseAudioBackend) Subscribe() (<-chan VolumeEvent, error) {
+	var startErr error
+
+	p.subscribeOnce.Do(func() {
+		cmd := exec.Command("pactl", "subscribe")
+
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			startErr = fmt.Errorf("open pactl subscribe stdout: %w", err)
+			return
+		}
+
+		if err := cmd.Start(); err != nil {
+			startErr = fmt.Errorf("start pactl subscribe: %w", err)
+			return
+		}
+
+		p.subscribeMutex.Lock()
+		p.subscribeCmd = cmd
+		p.subscribeMutex.Unlock()
+
+		go p.watchEvents(stdout, cmd)
+	})
+
+	return p.events, startErr
+}
+
+// watchEvents reads `pactl subscribe` output until the subprocess exits (see
+// Shutdown, which kills it), then reaps it and closes p.events itself - so a
+// shutdown racing with an in-flight event can never send on a closed channel.
+func (p *pulseAudioBackend) watchEvents(stdout io.Reader, cmd *exec.Cmd) {
+	defer close(p.events)
+	defer cmd.Wait()
+
+	scanner := bufio.NewScanner(stdout)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.Contains(line, "sink-input") {
+			continue
+		}
+
+		index := parseSinkInputIndex(line)
+		if index == "" {
+			continue
+		}
+
+		infos, err := p.enumerateInfo()
+		if err != nil {
+			p.logger.Warnw("Failed to re-enumerate sink-inputs after change event", "error", err)
+			continue
+		}
+
+		for _, info := range infos {
+			if info.Session.Key != index {
+				continue
+			}
+
+			p.events <- VolumeEvent{Session: info.Session, Volume: info.Volume, Muted: info.Muted}
+			break
+		}
+	}
+}
+
+// parseSinkInputIndex pulls the index out of a `pactl subscribe` line, e.g.
+// "Event 'change' on sink-input #42" -> "42". It returns whatever follows the
+// last '#' without checking that the line is about a sink-input (watchEvents
+// filters for that first), and "" if the line has no '#'.
+func parseSinkInputIndex(line string) string {
+	i := strings.LastIndex(line, "#")
+	if i == -1 {
+		return ""
+	}
+
+	return strings.TrimSpace(line[i+1:])
+}
+
+// Shutdown kills the `pactl subscribe` subprocess, which ends watchEvents'
+// scanner loop; watchEvents closes p.events itself once it has actually
+// stopped producing, rather than racing a send against a close here.
+func (p *pulseAudioBackend) Shutdown() error {
+	p.subscribeMutex.Lock()
+	cmd := p.subscribeCmd
+	p.subscribeMutex.Unlock()
+
+	if cmd == nil || cmd.Process == nil {
+		close(p.events)
+		return nil
+	}
+
+	if err := cmd.Process.Kill(); err != nil {
+		return fmt.Errorf("kill pactl subscribe: %w", err)
+	}
+
+	return nil
+}
+
+// sinkInputInfo is a single `pactl list sink-inputs` entry: the Session deej
+// cares about, plus the volume/mute state watchEvents needs to report an
+// external change without deej having to ask pactl what it already told it.
+type sinkInputInfo struct {
+	Session Session
+	Volume  float32
+	Muted   bool
+}
+
+// parseSinkInputs turns `pactl list sink-inputs` output into sinkInputInfos,
+// using the sink-input index as the key and the application.name property
+// (when present) as the display name.
+func parseSinkInputs(output []byte) []sinkInputInfo {
+	var infos []sinkInputInfo
+	var current sinkInputInfo
+	haveCurrent := false
+
+	flush := func() {
+		if haveCurrent && current.Session.Key != "" {
+			infos = append(infos, current)
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "Sink Input #"):
+			flush()
+			current = sinkInputInfo{Session: Session{Key: strings.TrimPrefix(line, "Sink Input #")}}
+			haveCurrent = true
+		case strings.HasPrefix(line, "application.name = "):
+			current.Session.DisplayName = strings.Trim(strings.TrimPrefix(line, "application.name = "), `"`)
+		case strings.HasPrefix(line, "Volume:"):
+			if volume, ok := parseVolumePercent(line); ok {
+				current.Volume = volume
+			}
+		case strings.HasPrefix(line, "Mute:"):
+			current.Muted = strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(line, "Mute:")), "yes")
+		}
+	}
+
+	flush()
+
+	return infos
+}
+
+// parseVolumePercent pulls the first "NN%" field out of a `pactl` Volume:
+// line (which repeats one per channel, e.g. "front-left: ... / 70% / ...")
+// and returns it in deej's canonical 0.0-1.0 range.
+func parseVolumePercent(line string) (float32, bool) {
+	for _, field := range strings.Split(line, "/") {
+		field = strings.TrimSpace(field)
+		if !strings.HasSuffix(field, "%") {
+			continue
+		}
+
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(field, "%"), 32)
+		if err != nil {
+			continue
+		}
+
+		return float32(percent) / 100, true
+	}
+
+	return 0, false
+}
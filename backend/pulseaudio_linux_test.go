@@ -0,0 +1,94 @@
+//go:build linux
+
+package backend
+
+import "testing"
+
+func TestParseVolumePercent(t *testing.T) {
+	cases := []struct {
+		name   string
+		line   string
+		want   float32
+		wantOk bool
+	}{
+		{
+			name:   "stereo",
+			line:   "Volume: front-left: 45875 /  70% / -7.36 dB,   front-right: 45875 /  70% / -7.36 dB",
+			want:   0.7,
+			wantOk: true,
+		},
+		{
+			name:   "mono",
+			line:   "Volume: mono: 65536 / 100% / 0.00 dB",
+			want:   1.0,
+			wantOk: true,
+		},
+		{
+			name:   "base volume line",
+			line:   "Volume: base volume: 65536 / 100% / 0.00 dB",
+			want:   1.0,
+			wantOk: true,
+		},
+		{
+			name:   "not a volume line",
+			line:   "Mute: no",
+			want:   0,
+			wantOk: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := parseVolumePercent(c.line)
+			if ok != c.wantOk {
+				t.Fatalf("parseVolumePercent(%q) ok = %v, want %v", c.line, ok, c.wantOk)
+			}
+
+			if ok && got != c.want {
+				t.Fatalf("parseVolumePercent(%q) = %v, want %v", c.line, got, c.want)
+			}
+		})
+	}
+}
+
+func TestParseSinkInputs(t *testing.T) {
+	output := `Sink Input #42
+	Driver: protocol-native.c
+	Volume: front-left: 45875 /  70% / -7.36 dB,   front-right: 45875 /  70% / -7.36 dB
+	Mute: no
+	Sink Input #7
+	Properties:
+		application.name = "Firefox"
+		Volume: mono: 32768 /  50% / -18.06 dB
+		Mute: yes
+`
+
+	infos := parseSinkInputs([]byte(output))
+
+	if len(infos) != 2 {
+		t.Fatalf("got %d sink inputs, want 2", len(infos))
+	}
+
+	if infos[0].Session.Key != "42" || infos[0].Volume != 0.7 || infos[0].Muted {
+		t.Fatalf("sink input #42 parsed as %+v", infos[0])
+	}
+
+	if infos[1].Session.Key != "7" || infos[1].Session.DisplayName != "Firefox" || infos[1].Volume != 0.5 || !infos[1].Muted {
+		t.Fatalf("sink input #7 parsed as %+v", infos[1])
+	}
+}
+
+func TestParseSinkInputIndex(t *testing.T) {
+	cases := map[string]string{
+		"Event 'change' on sink-input #42": "42",
+		"Event 'new' on sink-input #0":     "0",
+		"Event 'change' on sink #3":        "3",
+		"no index here":                    "",
+	}
+
+	for line, want := range cases {
+		if got := parseSinkInputIndex(line); got != want {
+			t.Errorf("parseSinkInputIndex(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+//go:build windows
+
+package backend
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/moutend/go-wca/pkg/wca"
+	"go.uber.org/zap"
+	"golang.org/x/sys/windows"
+)
+
+// wcaSessionFinder is the real sessionFinder implementation on Windows,
+// enumerating audio sessions through WASAPI (via go-wca) against the
+// default render endpoint.
+type wcaSessionFinder struct {
+	logger *zap.SugaredLogger
+
+	deviceEnumerator *wca.IMMDeviceEnumerator
+	device           *wca.IMMDevice
+	sessionManager   *wca.IAudioSessionManager2
+}
+
+// newSessionFinder sets up COM and binds to the default audio render
+// endpoint's session manager, ready to enumerate sessions on demand.
+func newSessionFinder(logger *zap.SugaredLogger) (sessionFinder, error) {
+	var deviceEnumerator *wca.IMMDeviceEnumerator
+
+	if err := wca.CoCreateInstance(
+		wca.CLSID_MMDeviceEnumerator,
+		0,
+		wca.CLSCTX_ALL,
+		wca.IID_IMMDeviceEnumerator,
+		&deviceEnumerator,
+	); err != nil {
+		return nil, fmt.Errorf("create device enumerator: %w", err)
+	}
+
+	var device *wca.IMMDevice
+	if err := deviceEnumerator.GetDefaultAudioEndpoint(wca.ERender, wca.EConsole, &device); err != nil {
+		deviceEnumerator.Release()
+		return nil, fmt.Errorf("get default render endpoint: %w", err)
+	}
+
+	var sessionManager *wca.IAudioSessionManager2
+	if err := device.Activate(
+		wca.IID_IAudioSessionManager2,
+		wca.CLSCTX_ALL,
+		nil,
+		&sessionManager,
+	); err != nil {
+		device.Release()
+		deviceEnumerator.Release()
+		return nil, fmt.Errorf("activate session manager: %w", err)
+	}
+
+	return &wcaSessionFinder{
+		logger:           logger,
+		deviceEnumerator: deviceEnumerator,
+		device:           device,
+		sessionManager:   sessionManager,
+	}, nil
+}
+
+// GetAllSessions enumerates every active audio session on the render
+// endpoint, skipping ones whose process name can't be resolved.
+func (f *wcaSessionFinder) GetAllSessions() ([]osSession, error) {
+	var sessionEnumerator *wca.IAudioSessionEnumerator
+	if err := f.sessionManager.GetSessionEnumerator(&sessionEnumerator); err != nil {
+		return nil, fmt.Errorf("get session enumerator: %w", err)
+	}
+	defer sessionEnumerator.Release()
+
+	var count int
+	if err := sessionEnumerator.GetCount(&count); err != nil {
+		return nil, fmt.Errorf("get session count: %w", err)
+	}
+
+	sessions := make([]osSession, 0, count)
+
+	for i := 0; i < count; i++ {
+		var control *wca.IAudioSessionControl
+		if err := sessionEnumerator.GetSession(i, &control); err != nil {
+			f.logger.Warnw("Failed to get session control", "index", i, "error", err)
+			continue
+		}
+
+		session, err := newWCASession(control)
+		if err != nil {
+			f.logger.Warnw("Failed to adapt session control", "index", i, "error", err)
+			control.Release()
+			continue
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// GetSession resolves a single session by the key returned from
+// GetAllSessions, re-enumerating since WASAPI has no lookup-by-key API.
+func (f *wcaSessionFinder) GetSession(key string) (osSession, error) {
+	sessions, err := f.GetAllSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sessions {
+		if s.Key() == key {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no windows session found with key %q", key)
+}
+
+// Release tears down the COM objects acquired by newSessionFinder.
+func (f *wcaSessionFinder) Release() {
+	f.sessionManager.Release()
+	f.device.Release()
+	f.deviceEnumerator.Release()
+}
+
+// wcaSession adapts an IAudioSessionControl2/ISimpleAudioVolume pair to the
+// osSession interface.
+type wcaSession struct {
+	key    string
+	volume *wca.ISimpleAudioVolume
+}
+
+func newWCASession(control *wca.IAudioSessionControl) (*wcaSession, error) {
+	var control2 *wca.IAudioSessionControl2
+	if err := control.QueryInterface(wca.IID_IAudioSessionControl2, &control2); err != nil {
+		return nil, fmt.Errorf("query IAudioSessionControl2: %w", err)
+	}
+	defer control2.Release()
+
+	var pid uint32
+	if err := control2.GetProcessId(&pid); err != nil {
+		return nil, fmt.Errorf("get session process id: %w", err)
+	}
+
+	name, err := processName(pid)
+	if err != nil {
+		return nil, fmt.Errorf("resolve process name for pid %d: %w", pid, err)
+	}
+
+	var volume *wca.ISimpleAudioVolume
+	if err := control.QueryInterface(wca.IID_ISimpleAudioVolume, &volume); err != nil {
+		return nil, fmt.Errorf("query ISimpleAudioVolume: %w", err)
+	}
+
+	return &wcaSession{key: strings.ToLower(name), volume: volume}, nil
+}
+
+// processName resolves a PID to its executable's base name (e.g.
+// "spotify.exe"), which is what config session names are matched against.
+func processName(pid uint32) (string, error) {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return "", fmt.Errorf("open process: %w", err)
+	}
+	defer windows.CloseHandle(handle)
+
+	buf := make([]uint16, windows.MAX_PATH)
+	size := uint32(len(buf))
+
+	if err := windows.QueryFullProcessImageName(handle, 0, &buf[0], &size); err != nil {
+		return "", fmt.Errorf("query process image name: %w", err)
+	}
+
+	return filepath.Base(windows.UTF16ToString(buf[:size])), nil
+}
+
+func (s *wcaSession) Key() string {
+	return s.key
+}
+
+func (s *wcaSession) SetVolume(volume float32) error {
+	if err := s.volume.SetMasterVolume(volume, nil); err != nil {
+		return fmt.Errorf("set master volume: %w", err)
+	}
+
+	return nil
+}
+
+func (s *wcaSession) SetMute(muted bool) error {
+	if err := s.volume.SetMute(muted, nil); err != nil {
+		return fmt.Errorf("set mute: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Command deej is the machine-side client that pairs with an Arduino chip to
+// form a tactile, physical volume control system.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/omriharel/deej"
+)
+
+func main() {
+	var foreground bool
+	var noTray bool
+	var configPath string
+	var logLevel string
+	var printConfigPath bool
+
+	flag.BoolVar(&foreground, "foreground", false, "run in the foreground, without a tray icon")
+	flag.BoolVar(&foreground, "f", false, "shorthand for -foreground")
+	flag.StringVar(&configPath, "config", envOrDefault("DEEJ_CONFIG", ""), "path to config.yaml (default: search the usual locations)")
+	flag.StringVar(&logLevel, "log-level", envOrDefault("LOG_LEVEL", "info"), "log level: debug, info, or error")
+	flag.BoolVar(&noTray, "no-tray", false, "equivalent to the DEEJ_NO_TRAY_ICON envvar")
+	flag.BoolVar(&printConfigPath, "print-config-path", false, "print the config file that would be used, then exit")
+	flag.Parse()
+
+	if printConfigPath {
+		path, _ := deej.ResolveConfigPath(configPath)
+		fmt.Println(path)
+		return
+	}
+
+	logger, err := newLogger(logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create logger: %s\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	d, err := deej.NewDeej(logger, deej.Options{
+		ConfigPath: configPath,
+		NoTray:     noTray || foreground,
+	})
+	if err != nil {
+		logger.Fatalw("Failed to create deej instance", "error", err)
+	}
+
+	if err := d.Initialize(); err != nil {
+		logger.Fatalw("Failed to initialize deej", "error", err)
+	}
+}
+
+// newLogger builds a zap logger whose level is driven by the given string
+// (one of "debug", "info" or "error"), falling back to info for anything else.
+func newLogger(level string) (*zap.SugaredLogger, error) {
+	var zapLevel zapcore.Level
+
+	switch level {
+	case "debug":
+		zapLevel = zapcore.DebugLevel
+	case "error":
+		zapLevel = zapcore.ErrorLevel
+	default:
+		zapLevel = zapcore.InfoLevel
+	}
+
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(zapLevel)
+
+	logger, err := config.Build()
+	if err != nil {
+		return nil, fmt.Errorf("build zap logger: %w", err)
+	}
+
+	return logger.Sugar(), nil
+}
+
+// envOrDefault returns the value of the given environment variable, or
+// fallback if it's unset.
+func envOrDefault(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+
+	return fallback
+}
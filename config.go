@@ -0,0 +1,468 @@
+package deej
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const configFilepath = "config.yaml"
+
+// defaultConfigYAML is written to the best writable search location when no
+// config file is found anywhere deej looks on first run.
+//go:embed config.default.yaml
+var defaultConfigYAML []byte
+
+// ConfigTopic identifies a single kind of config change that subsystems can
+// subscribe to independently, instead of reacting to every file write by
+// tearing everything down and starting over.
+type ConfigTopic string
+
+const (
+
+	// SliderMappingChanged fires when the slider-to-session bindings change.
+	// It's the only topic with a subscriber today (see deej.go's
+	// watchSliderMappingChanges); ConnectionInfo, InvertSliders and
+	// NoiseReductionLevel are still loaded from config.yaml but have no
+	// subsystem that reacts to them changing yet, so there's no topic for
+	// them until one does.
+	SliderMappingChanged ConfigTopic = "slider_mapping_changed"
+)
+
+// ConfigChange carries the old and new value of whatever changed under a
+// given topic. Old is nil on the very first load, since there's nothing to
+// compare against yet.
+type ConfigChange struct {
+	Topic ConfigTopic
+	Old   interface{}
+	New   interface{}
+}
+
+// sliderMapConfig is the raw `slider_mapping:` section of config.yaml before
+// normalization: a slider index mapped to either a single session name or a
+// list of them, e.g.
+//
+//	slider_mapping:
+//	  0: master
+//	  1: [chrome.exe, firefox.exe]
+type sliderMapConfig map[int]interface{}
+
+// asSliderMap normalizes conf's per-slider value - a bare string or a list of
+// strings - into a sliderMap, discarding any entry whose value is neither.
+func (conf sliderMapConfig) asSliderMap() *sliderMap {
+	sm := newSliderMap()
+
+	for sliderID, rawTargets := range conf {
+		switch targets := rawTargets.(type) {
+		case string:
+			sm.set(sliderID, []string{targets})
+		case []interface{}:
+			names := make([]string, 0, len(targets))
+			for _, target := range targets {
+				if name, ok := target.(string); ok {
+					names = append(names, name)
+				}
+			}
+			sm.set(sliderID, names)
+		}
+	}
+
+	return sm
+}
+
+// sliderMap is the normalized form of sliderMapConfig: each slider index maps
+// to an ordered list of session names it should control.
+type sliderMap struct {
+	m map[int][]string
+}
+
+// newSliderMap creates an empty sliderMap.
+func newSliderMap() *sliderMap {
+	return &sliderMap{m: make(map[int][]string)}
+}
+
+// set assigns targetNames to sliderID, replacing whatever was there before.
+func (sm *sliderMap) set(sliderID int, targetNames []string) {
+	sm.m[sliderID] = targetNames
+}
+
+// iterate calls f once per slider index in sm, in no particular order. It's
+// a no-op on a nil sliderMap.
+func (sm *sliderMap) iterate(f func(sliderID int, targetNames []string)) {
+	if sm == nil {
+		return
+	}
+
+	for sliderID, targetNames := range sm.m {
+		f(sliderID, targetNames)
+	}
+}
+
+// equals reports whether sm and other map every slider index to the same
+// ordered list of session names. A nil sliderMap only equals another nil
+// sliderMap.
+func (sm *sliderMap) equals(other *sliderMap) bool {
+	if sm == nil || other == nil {
+		return sm == other
+	}
+
+	if len(sm.m) != len(other.m) {
+		return false
+	}
+
+	for sliderID, targetNames := range sm.m {
+		otherNames, ok := other.m[sliderID]
+		if !ok || len(targetNames) != len(otherNames) {
+			return false
+		}
+
+		for i, name := range targetNames {
+			if name != otherNames[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// connectionInfoConfig is the `connection_info:` section of config.yaml,
+// controlling the serial connection to the arduino.
+type connectionInfoConfig struct {
+	COMPort  string `mapstructure:"com_port"`
+	BaudRate int    `mapstructure:"baud_rate"`
+}
+
+// httpAPIConfig is the `http_api:` section of config.yaml, controlling the
+// optional HTTP control server.
+type httpAPIConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Address     string `mapstructure:"address"`
+	AuthToken   string `mapstructure:"authToken"`
+	TLSCertFile string `mapstructure:"tlsCertFile"`
+	TLSKeyFile  string `mapstructure:"tlsKeyFile"`
+}
+
+// displayConfig is the `Display:` section of config.yaml, controlling the
+// optional physical screen.
+type displayConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Driver  string `mapstructure:"driver"`
+	Bus     string `mapstructure:"bus"`
+	Address uint16 `mapstructure:"address"`
+	Width   int    `mapstructure:"width"`
+	Height  int    `mapstructure:"height"`
+	Layout  string `mapstructure:"layout"`
+
+	// Pins lists GPIO pin names for bit-banged drivers (currently hd44780);
+	// see display.Options.Pins.
+	Pins []string `mapstructure:"pins"`
+
+	MockOutputPath string `mapstructure:"mockOutputPath"`
+}
+
+// CanonicalConfig is deej's in-memory view of config.yaml, kept up to date
+// with the file on disk by WatchConfigFileChanges.
+type CanonicalConfig struct {
+	SliderMapping *sliderMap
+
+	ConnectionInfo connectionInfoConfig
+
+	InvertSliders       bool
+	NoiseReductionLevel string
+
+	HTTPAPI httpAPIConfig
+
+	// Backends lists, by name, the session backends (see package backend)
+	// that should be initialized for this run.
+	Backends []string
+
+	// ControlSocket is the Unix domain socket path (or, on Windows, named
+	// pipe name) the UAPI control socket listens on. Empty means "use the
+	// platform default".
+	ControlSocket string
+
+	Display displayConfig
+
+	logger     *zap.SugaredLogger
+	notifier   Notifier
+	configPath string
+
+	subscribersMutex sync.Mutex
+	subscribers      map[ConfigTopic][]chan<- ConfigChange
+
+	stopWatcherChannel chan bool
+}
+
+// NewConfig creates a CanonicalConfig instance and binds it to the given
+// notifier for user-facing error reporting. If configPath is empty, Load
+// searches the usual locations (and, as a last resort, writes a default
+// config) instead of assuming config.yaml in the working directory.
+func NewConfig(logger *zap.SugaredLogger, notifier Notifier, configPath string) (*CanonicalConfig, error) {
+	logger = logger.Named("config")
+
+	cc := &CanonicalConfig{
+		logger:             logger,
+		notifier:           notifier,
+		configPath:         configPath,
+		subscribers:        make(map[ConfigTopic][]chan<- ConfigChange),
+		stopWatcherChannel: make(chan bool),
+	}
+
+	logger.Debug("Created config instance")
+
+	return cc, nil
+}
+
+// Subscribe registers ch to receive a ConfigChange every time the given
+// topic fires. Subscribers are expected to register once at startup and
+// drain their channel for as long as deej runs; Subscribe never closes ch.
+func (cc *CanonicalConfig) Subscribe(topic ConfigTopic, ch chan<- ConfigChange) {
+	cc.subscribersMutex.Lock()
+	defer cc.subscribersMutex.Unlock()
+
+	cc.subscribers[topic] = append(cc.subscribers[topic], ch)
+}
+
+// publish fans a ConfigChange out to every subscriber of its topic. Slow
+// subscribers don't block the publisher - a channel that's not ready to
+// receive simply misses that update rather than stalling config reload.
+func (cc *CanonicalConfig) publish(change ConfigChange) {
+	cc.subscribersMutex.Lock()
+	defer cc.subscribersMutex.Unlock()
+
+	for _, ch := range cc.subscribers[change.Topic] {
+		select {
+		case ch <- change:
+		default:
+			cc.logger.Warnw("Subscriber channel full, dropping config change", "topic", change.Topic)
+		}
+	}
+}
+
+// Load reads config.yaml from disk into the CanonicalConfig, then diffs the
+// newly loaded values against whatever was there before and publishes a
+// ConfigChange per topic whose value actually changed.
+func (cc *CanonicalConfig) Load() error {
+	cc.logger.Debug("Loading config")
+
+	if cc.configPath == "" {
+		path, exists := ResolveConfigPath("")
+
+		if !exists {
+			if err := writeDefaultConfig(path); err != nil {
+				return fmt.Errorf("write default config: %w", err)
+			}
+
+			cc.notifier.Notify("deej", fmt.Sprintf("No config file found, wrote defaults to %s", path))
+			cc.logger.Infow("Wrote default config", "path", path)
+		}
+
+		cc.configPath = path
+	}
+
+	viper.SetConfigFile(cc.configPath)
+
+	if err := viper.ReadInConfig(); err != nil {
+		cc.logger.Warnw("Failed to read config file", "error", err)
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	previous := cc.snapshot()
+
+	var fresh struct {
+		SliderMapping       sliderMapConfig      `mapstructure:"slider_mapping"`
+		ConnectionInfo      connectionInfoConfig `mapstructure:"connection_info"`
+		InvertSliders       bool                 `mapstructure:"invert_sliders"`
+		NoiseReductionLevel string               `mapstructure:"noise_reduction_level"`
+		HTTPAPI             httpAPIConfig        `mapstructure:"http_api"`
+		Backends            []string             `mapstructure:"backends"`
+		ControlSocket       string               `mapstructure:"control_socket"`
+		Display             displayConfig        `mapstructure:"display"`
+	}
+
+	if err := viper.Unmarshal(&fresh); err != nil {
+		cc.logger.Warnw("Failed to unmarshal config", "error", err)
+		return fmt.Errorf("unmarshal config: %w", err)
+	}
+
+	cc.SliderMapping = fresh.SliderMapping.asSliderMap()
+	cc.ConnectionInfo = fresh.ConnectionInfo
+	cc.InvertSliders = fresh.InvertSliders
+	cc.NoiseReductionLevel = fresh.NoiseReductionLevel
+	cc.HTTPAPI = fresh.HTTPAPI
+	cc.Backends = fresh.Backends
+	cc.ControlSocket = fresh.ControlSocket
+	cc.Display = fresh.Display
+
+	cc.publishDiff(previous)
+
+	return nil
+}
+
+// configSnapshot holds just the fields publishDiff compares, so taking one
+// never copies CanonicalConfig's mutex.
+type configSnapshot struct {
+	sliderMapping *sliderMap
+}
+
+func (cc *CanonicalConfig) snapshot() configSnapshot {
+	return configSnapshot{
+		sliderMapping: cc.SliderMapping,
+	}
+}
+
+// publishDiff compares the just-loaded values against a snapshot taken
+// before the load and publishes a ConfigChange for each topic whose value
+// differs. It's separate from Load so the diffing logic stays easy to follow.
+func (cc *CanonicalConfig) publishDiff(previous configSnapshot) {
+	if !cc.SliderMapping.equals(previous.sliderMapping) {
+		// leave Old as an untyped nil on first load, so Old == nil holds
+		var old interface{}
+		if previous.sliderMapping != nil {
+			old = previous.sliderMapping
+		}
+
+		cc.publish(ConfigChange{Topic: SliderMappingChanged, Old: old, New: cc.SliderMapping})
+	}
+}
+
+// WatchConfigFileChanges blocks, reloading the config (and publishing
+// whatever changed) every time config.yaml is written to, until
+// StopWatchingConfigFile is called.
+func (cc *CanonicalConfig) WatchConfigFileChanges() {
+	cc.logger.Debug("Watching config file for changes")
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		cc.logger.Warnw("Failed to create config file watcher", "error", err)
+		return
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(cc.configPath); err != nil {
+		cc.logger.Warnw("Failed to watch config file", "error", err)
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op&fsnotify.Write == fsnotify.Write {
+				cc.logger.Info("Config file changed, reloading")
+
+				if err := cc.Load(); err != nil {
+					cc.logger.Warnw("Failed to reload config", "error", err)
+				}
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			cc.logger.Warnw("Config file watcher error", "error", err)
+		case <-cc.stopWatcherChannel:
+			return
+		}
+	}
+}
+
+// StopWatchingConfigFile stops WatchConfigFileChanges's loop.
+func (cc *CanonicalConfig) StopWatchingConfigFile() {
+	cc.stopWatcherChannel <- true
+}
+
+// ResolveConfigPath returns the config file deej would use, without loading
+// it or writing anything to disk. If override is non-empty (as set by
+// --config or $DEEJ_CONFIG), it's returned as-is along with whether it
+// exists. Otherwise, the first existing candidate from searchConfigPaths is
+// returned, or - if none exist - the path a default config would be written
+// to.
+func ResolveConfigPath(override string) (path string, exists bool) {
+	if override != "" {
+		_, err := os.Stat(override)
+		return override, err == nil
+	}
+
+	for _, candidate := range searchConfigPaths() {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+	}
+
+	return defaultConfigWriteTarget(), false
+}
+
+// searchConfigPaths lists, in priority order, every location deej looks for
+// a config file: config.yaml in the working directory, under the user's XDG
+// config directory (or %APPDATA% on Windows), and finally, except on
+// Windows, the system-wide /etc/deej location.
+func searchConfigPaths() []string {
+	paths := []string{configFilepath}
+
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			paths = append(paths, filepath.Join(appData, "deej", configFilepath))
+		}
+
+		return paths
+	}
+
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		paths = append(paths, filepath.Join(xdgConfigHome, "deej", configFilepath))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "deej", configFilepath))
+	}
+
+	paths = append(paths, filepath.Join("/etc", "deej", configFilepath))
+
+	return paths
+}
+
+// defaultConfigWriteTarget picks the best writable location for a freshly
+// written default config, mirroring the platform-specific entry in
+// searchConfigPaths.
+func defaultConfigWriteTarget() string {
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "deej", configFilepath)
+		}
+
+		return configFilepath
+	}
+
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		return filepath.Join(xdgConfigHome, "deej", configFilepath)
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "deej", configFilepath)
+	}
+
+	return configFilepath
+}
+
+// writeDefaultConfig writes the embedded default config to path, creating
+// its parent directory if necessary.
+func writeDefaultConfig(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, defaultConfigYAML, 0o644); err != nil {
+		return fmt.Errorf("write config file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package deej
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSliderMapConfigAsSliderMap(t *testing.T) {
+	conf := sliderMapConfig{
+		0: "master",
+		1: []interface{}{"chrome.exe", "firefox.exe"},
+		2: 42, // not a string or []interface{}: discarded
+	}
+
+	sm := conf.asSliderMap()
+
+	var got []string
+	sm.iterate(func(sliderID int, targetNames []string) {
+		if sliderID == 0 {
+			got = targetNames
+		}
+	})
+
+	if len(got) != 1 || got[0] != "master" {
+		t.Fatalf("slider 0: got %v, want [master]", got)
+	}
+
+	var gotMulti []string
+	sm.iterate(func(sliderID int, targetNames []string) {
+		if sliderID == 1 {
+			gotMulti = targetNames
+		}
+	})
+
+	if len(gotMulti) != 2 || gotMulti[0] != "chrome.exe" || gotMulti[1] != "firefox.exe" {
+		t.Fatalf("slider 1: got %v, want [chrome.exe firefox.exe]", gotMulti)
+	}
+
+	found := false
+	sm.iterate(func(sliderID int, targetNames []string) {
+		if sliderID == 2 {
+			found = true
+		}
+	})
+
+	if found {
+		t.Fatalf("slider 2 should have been discarded, has non-string/list value")
+	}
+}
+
+func TestSliderMapEquals(t *testing.T) {
+	a := newSliderMap()
+	a.set(0, []string{"master"})
+	a.set(1, []string{"chrome.exe", "firefox.exe"})
+
+	b := newSliderMap()
+	b.set(0, []string{"master"})
+	b.set(1, []string{"chrome.exe", "firefox.exe"})
+
+	if !a.equals(b) {
+		t.Fatalf("expected equal maps to be equal")
+	}
+
+	c := newSliderMap()
+	c.set(0, []string{"master"})
+	c.set(1, []string{"firefox.exe", "chrome.exe"}) // different order
+
+	if a.equals(c) {
+		t.Fatalf("expected differently-ordered targets to be unequal")
+	}
+
+	d := newSliderMap()
+	d.set(0, []string{"master"})
+
+	if a.equals(d) {
+		t.Fatalf("expected maps with different slider counts to be unequal")
+	}
+
+	var nilMap *sliderMap
+
+	if !nilMap.equals(nil) {
+		t.Fatalf("expected two nil sliderMaps to be equal")
+	}
+
+	if nilMap.equals(a) || a.equals(nilMap) {
+		t.Fatalf("expected a nil sliderMap to be unequal to a non-nil one")
+	}
+}
+
+func TestResolveConfigPathOverride(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "override.yaml")
+
+	if err := os.WriteFile(existing, []byte("slider_mapping:\n"), 0o644); err != nil {
+		t.Fatalf("write temp config: %v", err)
+	}
+
+	if path, exists := ResolveConfigPath(existing); !exists || path != existing {
+		t.Fatalf("ResolveConfigPath(%q) = (%q, %v), want (%q, true)", existing, path, exists, existing)
+	}
+
+	missing := filepath.Join(dir, "missing.yaml")
+
+	if path, exists := ResolveConfigPath(missing); exists || path != missing {
+		t.Fatalf("ResolveConfigPath(%q) = (%q, %v), want (%q, false)", missing, path, exists, missing)
+	}
+}
+
+func TestResolveConfigPathXDGFallback(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	want := filepath.Join(dir, "deej", configFilepath)
+
+	if path, exists := ResolveConfigPath(""); exists || path != want {
+		t.Fatalf("ResolveConfigPath(\"\") = (%q, %v), want (%q, false)", path, exists, want)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "deej"), 0o755); err != nil {
+		t.Fatalf("create deej config dir: %v", err)
+	}
+
+	if err := os.WriteFile(want, []byte("slider_mapping:\n"), 0o644); err != nil {
+		t.Fatalf("write xdg config: %v", err)
+	}
+
+	if path, exists := ResolveConfigPath(""); !exists || path != want {
+		t.Fatalf("ResolveConfigPath(\"\") = (%q, %v), want (%q, true)", path, exists, want)
+	}
+}
@@ -5,9 +5,16 @@ package deej
 import (
 	"fmt"
 	"os"
+	"runtime"
+	"strings"
+	"sync"
 
 	"go.uber.org/zap"
 
+	"github.com/omriharel/deej/api"
+	"github.com/omriharel/deej/backend"
+	"github.com/omriharel/deej/display"
+	"github.com/omriharel/deej/uapi"
 	"github.com/omriharel/deej/util"
 )
 
@@ -17,26 +24,63 @@ const (
 	envNoTray = "DEEJ_NO_TRAY_ICON"
 )
 
+// Options configures how a Deej instance is constructed, letting callers
+// (the CLI, tests, headless deployments) choose a notifier, config path and
+// tray behavior instead of NewDeej hard-coding them or Initialize reading
+// the environment directly.
+type Options struct {
+
+	// Notifier is used for user-facing notifications. If nil, NewDeej falls
+	// back to the platform's default (NewToastNotifier).
+	Notifier Notifier
+
+	// ConfigPath overrides the config file location. If empty, the default
+	// search behavior is used.
+	ConfigPath string
+
+	// NoTray skips the tray icon and runs deej in the foreground, waiting on
+	// an interrupt signal to shut down. Equivalent to the DEEJ_NO_TRAY_ICON
+	// environment variable, which is still honored if this is false.
+	NoTray bool
+}
+
 // Deej is the main entity managing access to all sub-components
 type Deej struct {
 	logger   *zap.SugaredLogger
 	notifier Notifier
 	config   *CanonicalConfig
+	options  Options
+
+	apiServer  *api.Server
+	uapiServer *uapi.Server
+	display    display.Display
+
+	backends      map[string]backend.SessionBackend
+	sessionMapper *sessionMapper
+
+	sliderStateMutex sync.RWMutex
+	sliderState      map[int]api.SliderState
 
 	stopChannel chan bool
 }
 
-// NewDeej creates a Deej instance
-func NewDeej(logger *zap.SugaredLogger) (*Deej, error) {
+// NewDeej creates a Deej instance, using the defaults for anything left
+// unset on options.
+func NewDeej(logger *zap.SugaredLogger, options Options) (*Deej, error) {
 	logger = logger.Named("deej")
 
-	notifier, err := NewToastNotifier(logger)
-	if err != nil {
-		logger.Errorw("Failed to create ToastNotifier", "error", err)
-		return nil, fmt.Errorf("create new ToastNotifier: %w", err)
+	notifier := options.Notifier
+	if notifier == nil {
+		var err error
+
+		notifier, err = NewToastNotifier(logger)
+		if err != nil {
+			logger.Errorw("Failed to create ToastNotifier", "error", err)
+			return nil, fmt.Errorf("create new ToastNotifier: %w", err)
+		}
 	}
 
-	config, err := NewConfig(logger, notifier)
+	config, err := NewConfig(logger, notifier, options.ConfigPath)
 	if err != nil {
 		logger.Errorw("Failed to create Config", "error", err)
 		return nil, fmt.Errorf("create new Config: %w", err)
@@ -46,6 +90,8 @@ func NewDeej(logger *zap.SugaredLogger) (*Deej, error) {
 		logger:      logger,
 		notifier:    notifier,
 		config:      config,
+		options:     options,
+		sliderState: make(map[int]api.SliderState),
 		stopChannel: make(chan bool),
 	}
 
@@ -64,10 +110,22 @@ func (d *Deej) Initialize() error {
 		return fmt.Errorf("load config during init: %w", err)
 	}
 
+	if err := d.initializeBackends(); err != nil {
+		d.logger.Errorw("Failed to initialize session backends", "error", err)
+		return fmt.Errorf("initialize session backends: %w", err)
+	}
+
 	// decide whether to run with/without tray
-	if _, noTraySet := os.LookupEnv(envNoTray); noTraySet {
+	_, envNoTraySet := os.LookupEnv(envNoTray)
 
-		d.logger.Debugw("Running without tray icon", "reason", "envvar set")
+	if d.options.NoTray || envNoTraySet {
+
+		reason := "--no-tray flag set"
+		if envNoTraySet {
+			reason = "envvar set"
+		}
+
+		d.logger.Debugw("Running without tray icon", "reason", reason)
 
 		// run in main thread while waiting on ctrl+C
 		interruptChannel := util.SetupCloseHandler()
@@ -90,8 +148,64 @@ func (d *Deej) Initialize() error {
 func (d *Deej) run() {
 	d.logger.Info("Run loop starting")
 
-	// watch the config file for changes
+	// watch the config file for changes, and rebind the session mapper
+	// whenever the slider mapping itself changes - every consumer
+	// (HTTP API, UAPI, display) learns about it through the session
+	// mapper's own state publishing, so there's exactly one subscriber here
 	go d.config.WatchConfigFileChanges()
+	go d.watchSliderMappingChanges()
+
+	// start the HTTP control API, if the user's opted into it
+	if d.config.HTTPAPI.Enabled {
+		d.apiServer = api.NewServer(d.logger, api.Options{
+			Address:     d.config.HTTPAPI.Address,
+			AuthToken:   d.config.HTTPAPI.AuthToken,
+			TLSCertFile: d.config.HTTPAPI.TLSCertFile,
+			TLSKeyFile:  d.config.HTTPAPI.TLSKeyFile,
+		}, d)
+
+		if err := d.apiServer.Start(); err != nil {
+			d.logger.Errorw("Failed to start HTTP API server", "error", err)
+		}
+	}
+
+	// start the physical display, if one's configured
+	if d.config.Display.Enabled {
+		dsp, err := display.New(d.logger, display.Options{
+			Driver:         d.config.Display.Driver,
+			Bus:            d.config.Display.Bus,
+			Address:        d.config.Display.Address,
+			Width:          d.config.Display.Width,
+			Height:         d.config.Display.Height,
+			Layout:         d.config.Display.Layout,
+			Pins:           d.config.Display.Pins,
+			MockOutputPath: d.config.Display.MockOutputPath,
+		})
+		if err != nil {
+			d.logger.Errorw("Failed to initialize display", "error", err)
+		} else {
+			d.display = dsp
+		}
+	}
+
+	// start the UAPI control socket
+	socketPath := d.config.ControlSocket
+	if socketPath == "" {
+		socketPath = defaultControlSocketPath()
+	}
+
+	listener, err := uapi.Listen(socketPath)
+	if err != nil {
+		d.logger.Errorw("Failed to open control socket", "path", socketPath, "error", err)
+	} else {
+		d.uapiServer = uapi.NewServer(d.logger, d)
+
+		go func() {
+			if err := d.uapiServer.Serve(listener); err != nil {
+				d.logger.Debugw("Control socket listener stopped", "error", err)
+			}
+		}()
+	}
 
 	// wait until stopped (gracefully)
 	<-d.stopChannel
@@ -103,6 +217,26 @@ func (d *Deej) run() {
 	os.Exit(0)
 }
 
+// watchSliderMappingChanges subscribes to SliderMappingChanged and rebinds
+// the session mapper every time it fires, so editing the slider_mapping
+// section of config.yaml takes effect immediately - no restart, and no
+// reopening the serial port.
+func (d *Deej) watchSliderMappingChanges() {
+	changes := make(chan ConfigChange, 4)
+	d.config.Subscribe(SliderMappingChanged, changes)
+
+	for change := range changes {
+		mapping, ok := change.New.(*sliderMap)
+		if !ok {
+			d.logger.Warnw("Slider mapping change carried an unexpected type", "value", change.New)
+			continue
+		}
+
+		d.logger.Info("Slider mapping changed, rebinding sessions")
+		d.sessionMapper.rebind(mapping)
+	}
+}
+
 func (d *Deej) signalStop() {
 	d.logger.Debug("Signalling stop channel")
 	d.stopChannel <- true
@@ -112,5 +246,174 @@ func (d *Deej) stop() {
 	d.logger.Info("Stopping")
 
 	d.config.StopWatchingConfigFile()
+
+	if d.apiServer != nil {
+		d.apiServer.Stop()
+	}
+
+	if d.uapiServer != nil {
+		d.uapiServer.Stop()
+	}
+
+	if d.display != nil {
+		if err := d.display.Clear(); err != nil {
+			d.logger.Warnw("Failed to clear display on shutdown", "error", err)
+		}
+	}
+
+	for name, b := range d.backends {
+		if err := b.Shutdown(); err != nil {
+			d.logger.Warnw("Failed to shut down session backend", "backend", name, "error", err)
+		}
+	}
+
 	d.stopTray()
 }
+
+// defaultControlSocketPath returns the platform-appropriate default location
+// for the UAPI control socket when none is set in config.
+func defaultControlSocketPath() string {
+	if runtime.GOOS == "windows" {
+		return `\\.\pipe\deej`
+	}
+
+	return "/tmp/deej.sock"
+}
+
+// initializeBackends instantiates and initializes one SessionBackend per
+// name listed in the config's `backend:` section, keeping each one keyed by
+// name so sliders can be routed to it during mapping. A single slider can
+// drive sessions across more than one backend at once (e.g. a local
+// PulseAudio sink-input and a remote MPV socket).
+//
+// Once every backend is up, it builds the sessionMapper, performs the
+// initial slider->session resolution against the just-loaded config, and
+// starts a goroutine per backend to reflect that backend's own change events
+// (an app's volume changing outside deej) back into slider state.
+func (d *Deej) initializeBackends() error {
+	d.backends = make(map[string]backend.SessionBackend, len(d.config.Backends))
+
+	for _, name := range d.config.Backends {
+		sb, err := backend.Get(name)
+		if err != nil {
+			return fmt.Errorf("look up session backend %q: %w", name, err)
+		}
+
+		if err := sb.Initialize(d.logger); err != nil {
+			return fmt.Errorf("initialize session backend %q: %w", name, err)
+		}
+
+		d.backends[name] = sb
+	}
+
+	d.sessionMapper = newSessionMapper(d.logger, d)
+	d.sessionMapper.rebind(d.config.SliderMapping)
+
+	for name, sb := range d.backends {
+		go d.sessionMapper.watchBackend(name, sb)
+	}
+
+	return nil
+}
+
+// Sliders implements api.StateProvider, returning a snapshot of every
+// slider's last known value and bound sessions.
+func (d *Deej) Sliders() []api.SliderState {
+	d.sliderStateMutex.RLock()
+	defer d.sliderStateMutex.RUnlock()
+
+	sliders := make([]api.SliderState, 0, len(d.sliderState))
+	for _, state := range d.sliderState {
+		sliders = append(sliders, state)
+	}
+
+	return sliders
+}
+
+// SetSliderOverride implements api.StateProvider, setting a session's volume
+// once via the session mapper regardless of its slider's physical position.
+func (d *Deej) SetSliderOverride(sliderID int, sessionName string, volume float32) error {
+	if err := d.sessionMapper.setVolume(sliderID, sessionName, volume); err != nil {
+		return fmt.Errorf("set slider %d override: %w", sliderID, err)
+	}
+
+	d.updateSliderVolume(sliderID, volume)
+
+	return nil
+}
+
+// SetSliderMute implements api.StateProvider, toggling mute for a session
+// bound to a slider via the session mapper.
+func (d *Deej) SetSliderMute(sliderID int, sessionName string, muted bool) error {
+	if err := d.sessionMapper.setMute(sliderID, sessionName, muted); err != nil {
+		return fmt.Errorf("set slider %d mute: %w", sliderID, err)
+	}
+
+	return nil
+}
+
+// updateSliderVolume records sliderID's latest volume and publishes the
+// change to every subscribed consumer (HTTP API SSE stream, UAPI socket).
+// It's the single place a volume change - from the physical slider, a
+// backend event, or an HTTP override - ends up reflected everywhere.
+func (d *Deej) updateSliderVolume(sliderID int, volume float32) {
+	state := d.setSliderState(sliderID, func(s *api.SliderState) {
+		s.Value = volume
+	})
+
+	d.publishSliderState(state)
+}
+
+// updateSliderSessions records which session names are currently bound to
+// sliderID (as resolved by the session mapper) and publishes the change.
+func (d *Deej) updateSliderSessions(sliderID int, sessions []string) {
+	state := d.setSliderState(sliderID, func(s *api.SliderState) {
+		s.Sessions = sessions
+	})
+
+	d.publishSliderState(state)
+}
+
+// setSliderState applies mutate to sliderID's current state under
+// sliderStateMutex and returns the resulting snapshot.
+func (d *Deej) setSliderState(sliderID int, mutate func(*api.SliderState)) api.SliderState {
+	d.sliderStateMutex.Lock()
+	defer d.sliderStateMutex.Unlock()
+
+	state := d.sliderState[sliderID]
+	state.SliderID = sliderID
+
+	mutate(&state)
+
+	d.sliderState[sliderID] = state
+
+	return state
+}
+
+// publishSliderState fans a slider's latest state out to every subscribed
+// consumer that's currently running.
+func (d *Deej) publishSliderState(state api.SliderState) {
+	if d.apiServer != nil {
+		d.apiServer.PublishSliderEvent(state)
+	}
+
+	if d.uapiServer != nil {
+		d.uapiServer.PublishSliderEvent(state)
+	}
+
+	if d.display != nil {
+		if err := d.display.SetSliderLevel(state.SliderID, state.Value); err != nil {
+			d.logger.Warnw("Failed to update display level", "slider", state.SliderID, "error", err)
+		}
+
+		if err := d.display.SetSliderLabel(state.SliderID, strings.Join(state.Sessions, ", ")); err != nil {
+			d.logger.Warnw("Failed to update display label", "slider", state.SliderID, "error", err)
+		}
+	}
+}
+
+// ReloadConfig implements api.StateProvider by forcing a config reload, as if
+// the config file on disk had just changed.
+func (d *Deej) ReloadConfig() error {
+	return d.config.Load()
+}
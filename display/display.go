@@ -0,0 +1,87 @@
+// Package display drives an optional physical screen - an I2C/SPI OLED or
+// character LCD - that mirrors deej's slider state: a level bar per slider,
+// plus, on the character LCD, the name of whichever application is currently
+// bound to it (the pixel drivers draw the bars only). It's the
+// on-device counterpart to the tray icon, for setups where the controller
+// itself carries a screen.
+package display
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Display is implemented by every screen driver deej knows how to render to.
+type Display interface {
+
+	// SetSliderLabel sets the label shown for a slider - usually the name of
+	// the application currently bound to it.
+	SetSliderLabel(sliderID int, label string) error
+
+	// SetSliderLevel sets a slider's level bar, in the canonical 0.0-1.0 range.
+	SetSliderLevel(sliderID int, level float32) error
+
+	// ShowToast briefly overlays a short message (e.g. "muted", "reloaded")
+	// on top of the slider view.
+	ShowToast(message string) error
+
+	// Clear blanks the display.
+	Clear() error
+}
+
+// Options configures a display driver. Not every field is meaningful for
+// every driver - see each driver's doc comment.
+type Options struct {
+
+	// Driver selects the implementation: "ssd1306", "hd44780", or "mock".
+	Driver string
+
+	// Bus is the I2C/SPI bus device, e.g. "/dev/i2c-1".
+	Bus string
+
+	// Address is the device's I2C address.
+	Address uint16
+
+	// Width and Height describe the display geometry in pixels (OLED) or
+	// characters (LCD).
+	Width  int
+	Height int
+
+	// Layout names a driver-specific template describing where slider bars
+	// and labels are placed on the screen. None of the drivers read it; each
+	// uses its own fixed layout.
+	Layout string
+
+	// Pins lists GPIO pin names (as known to periph's gpioreg, e.g. "GPIO17")
+	// for drivers that bit-bang rather than talk over a bus. hd44780 expects
+	// exactly 6: rs, e, then the four data pins d4-d7.
+	Pins []string
+
+	// MockOutputPath is where the mock driver writes its rendered PNG frames.
+	MockOutputPath string
+}
+
+// registry holds every display driver registered at init time, keyed by the
+// name used in config. Platform-locked drivers (ssd1306, hd44780) only
+// register themselves from their linux-tagged files, so New still returns a
+// normal error - rather than a compile failure - for those drivers on other
+// platforms.
+var registry = map[string]func(*zap.SugaredLogger, Options) (Display, error){}
+
+// register makes a display driver available under the given name for later
+// lookup via New. It's meant to be called from the init() function of each
+// driver implementation file.
+func register(name string, factory func(*zap.SugaredLogger, Options) (Display, error)) {
+	registry[name] = factory
+}
+
+// New constructs the Display implementation named by options.Driver.
+func New(logger *zap.SugaredLogger, options Options) (Display, error) {
+	factory, ok := registry[options.Driver]
+	if !ok {
+		return nil, fmt.Errorf("unknown display driver %q", options.Driver)
+	}
+
+	return factory(logger, options)
+}
@@ -0,0 +1,190 @@
+//go:build linux
+
+package display
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/devices/v3/hd44780"
+	"periph.io/x/host/v3"
+)
+
+func init() {
+	register("hd44780", newHD44780Display)
+}
+
+// hd44780PinCount is the number of GPIO pins hd44780.New needs: rs, e, then
+// the four data pins (d4-d7) used in 4-bit mode.
+const hd44780PinCount = 6
+
+// hd44780Display renders slider levels and labels as text rows on a
+// character LCD (e.g. a 16x2 or 20x4 module) wired directly to GPIO in 4-bit
+// mode. Unlike the pixel-addressable SSD1306, bars are drawn as ASCII blocks
+// rather than pixels.
+type hd44780Display struct {
+	logger *zap.SugaredLogger
+
+	dev  *hd44780.Dev
+	cols int
+	rows int
+
+	mutex  sync.Mutex
+	labels map[int]string
+	levels map[int]float32
+}
+
+func newHD44780Display(logger *zap.SugaredLogger, options Options) (Display, error) {
+	logger = logger.Named("display.hd44780")
+
+	if len(options.Pins) != hd44780PinCount {
+		return nil, fmt.Errorf("hd44780 needs %d gpio pin names (rs, e, d4, d5, d6, d7), got %d", hd44780PinCount, len(options.Pins))
+	}
+
+	if _, err := host.Init(); err != nil {
+		return nil, fmt.Errorf("init periph host: %w", err)
+	}
+
+	pins := make([]gpio.PinIO, hd44780PinCount)
+	for i, name := range options.Pins {
+		pin := gpioreg.ByName(name)
+		if pin == nil {
+			return nil, fmt.Errorf("gpio pin %q not found", name)
+		}
+
+		pins[i] = pin
+	}
+
+	rs, e := pins[0], pins[1]
+	dataPins := []gpio.PinOut{pins[2], pins[3], pins[4], pins[5]}
+
+	dev, err := hd44780.New(dataPins, rs, e)
+	if err != nil {
+		return nil, fmt.Errorf("init hd44780 device: %w", err)
+	}
+
+	return &hd44780Display{
+		logger: logger,
+		dev:    dev,
+		cols:   options.Width,
+		rows:   options.Height,
+		labels: make(map[int]string),
+		levels: make(map[int]float32),
+	}, nil
+}
+
+func (d *hd44780Display) SetSliderLabel(sliderID int, label string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.labels[sliderID] = label
+
+	return d.render()
+}
+
+func (d *hd44780Display) SetSliderLevel(sliderID int, level float32) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.levels[sliderID] = level
+
+	return d.render()
+}
+
+func (d *hd44780Display) ShowToast(message string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.dev.SetCursor(uint8(d.rows-1), 0); err != nil {
+		return fmt.Errorf("set cursor for toast: %w", err)
+	}
+
+	return d.writeLine(padOrTrim(message, d.cols))
+}
+
+func (d *hd44780Display) Clear() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.labels = make(map[int]string)
+	d.levels = make(map[int]float32)
+
+	if err := d.dev.Reset(); err != nil {
+		return fmt.Errorf("reset hd44780: %w", err)
+	}
+
+	return nil
+}
+
+// render lays out one slider per row: a bracketed ASCII bar followed by the
+// slider's label, truncated to the display's column count. It must be called
+// with d.mutex held.
+func (d *hd44780Display) render() error {
+	if err := d.dev.Reset(); err != nil {
+		return fmt.Errorf("reset hd44780: %w", err)
+	}
+
+	row := 0
+	for sliderID, level := range d.levels {
+		if row >= d.rows {
+			break
+		}
+
+		if err := d.dev.SetCursor(uint8(row), 0); err != nil {
+			return fmt.Errorf("set cursor for row %d: %w", row, err)
+		}
+
+		line := fmt.Sprintf("%s %s", asciiBar(level, 8), d.labels[sliderID])
+
+		if err := d.writeLine(padOrTrim(line, d.cols)); err != nil {
+			return err
+		}
+
+		row++
+	}
+
+	return nil
+}
+
+func (d *hd44780Display) writeLine(line string) error {
+	if err := d.dev.Print(line); err != nil {
+		return fmt.Errorf("print line to hd44780: %w", err)
+	}
+
+	return nil
+}
+
+// asciiBar renders level (0.0-1.0) as a fixed-width bracketed bar of '#'
+// and '-' characters, e.g. "[###-----]".
+func asciiBar(level float32, width int) string {
+	switch {
+	case level < 0:
+		level = 0
+	case level > 1:
+		level = 1
+	}
+
+	filled := int(level * float32(width))
+
+	var b strings.Builder
+	b.WriteByte('[')
+	b.WriteString(strings.Repeat("#", filled))
+	b.WriteString(strings.Repeat("-", width-filled))
+	b.WriteByte(']')
+
+	return b.String()
+}
+
+// padOrTrim pads s with spaces up to width, or truncates it to width if
+// it's longer.
+func padOrTrim(s string, width int) string {
+	if len(s) >= width {
+		return s[:width]
+	}
+
+	return s + strings.Repeat(" ", width-len(s))
+}
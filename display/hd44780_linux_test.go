@@ -0,0 +1,49 @@
+//go:build linux
+
+package display
+
+import "testing"
+
+func TestAsciiBar(t *testing.T) {
+	cases := []struct {
+		name  string
+		level float32
+		width int
+		want  string
+	}{
+		{name: "empty", level: 0, width: 4, want: "[----]"},
+		{name: "full", level: 1, width: 4, want: "[####]"},
+		{name: "half", level: 0.5, width: 4, want: "[##--]"},
+		{name: "clamps below zero", level: -1, width: 4, want: "[----]"},
+		{name: "clamps above one", level: 2, width: 4, want: "[####]"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := asciiBar(c.level, c.width); got != c.want {
+				t.Fatalf("asciiBar(%v, %d) = %q, want %q", c.level, c.width, got, c.want)
+			}
+		})
+	}
+}
+
+func TestPadOrTrim(t *testing.T) {
+	cases := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{name: "pads", s: "ab", width: 5, want: "ab   "},
+		{name: "exact width", s: "abcde", width: 5, want: "abcde"},
+		{name: "trims", s: "abcdefgh", width: 5, want: "abcde"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := padOrTrim(c.s, c.width); got != c.want {
+				t.Fatalf("padOrTrim(%q, %d) = %q, want %q", c.s, c.width, got, c.want)
+			}
+		})
+	}
+}
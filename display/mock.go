@@ -0,0 +1,114 @@
+package display
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+func init() {
+	register("mock", newMockDisplay)
+}
+
+// mockDisplayWidth and mockDisplayHeight pick a reasonable frame size for the
+// mock driver when Options doesn't specify one.
+const (
+	mockDisplayWidth  = 128
+	mockDisplayHeight = 64
+)
+
+// mockDisplay renders the same layout as ssd1306Display, but to a PNG file on
+// disk instead of real hardware, so CI and local development can exercise
+// the display subsystem without an I2C bus.
+type mockDisplay struct {
+	logger     *zap.SugaredLogger
+	outputPath string
+
+	width, height int
+
+	mutex  sync.Mutex
+	labels map[int]string
+	levels map[int]float32
+}
+
+func newMockDisplay(logger *zap.SugaredLogger, options Options) (Display, error) {
+	width, height := options.Width, options.Height
+	if width == 0 {
+		width = mockDisplayWidth
+	}
+	if height == 0 {
+		height = mockDisplayHeight
+	}
+
+	outputPath := options.MockOutputPath
+	if outputPath == "" {
+		outputPath = "deej-display-mock.png"
+	}
+
+	return &mockDisplay{
+		logger:     logger.Named("display.mock"),
+		outputPath: outputPath,
+		width:      width,
+		height:     height,
+		labels:     make(map[int]string),
+		levels:     make(map[int]float32),
+	}, nil
+}
+
+func (d *mockDisplay) SetSliderLabel(sliderID int, label string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.labels[sliderID] = label
+
+	return d.render()
+}
+
+func (d *mockDisplay) SetSliderLevel(sliderID int, level float32) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.levels[sliderID] = level
+
+	return d.render()
+}
+
+// ShowToast only logs message at debug level; nothing is drawn into the PNG.
+func (d *mockDisplay) ShowToast(message string) error {
+	d.logger.Debugw("Toast", "message", message)
+	return nil
+}
+
+func (d *mockDisplay) Clear() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.labels = make(map[int]string)
+	d.levels = make(map[int]float32)
+
+	return d.render()
+}
+
+// render draws the current slider state to an RGBA canvas and writes it to
+// d.outputPath as a PNG. It must be called with d.mutex held.
+func (d *mockDisplay) render() error {
+	canvas := image.NewRGBA(image.Rect(0, 0, d.width, d.height))
+
+	drawSliders(canvas, d.labels, d.levels)
+
+	file, err := os.Create(d.outputPath)
+	if err != nil {
+		return fmt.Errorf("create mock display output file: %w", err)
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, canvas); err != nil {
+		return fmt.Errorf("encode mock display frame: %w", err)
+	}
+
+	return nil
+}
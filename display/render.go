@@ -0,0 +1,40 @@
+package display
+
+import (
+	"image/color"
+	"image/draw"
+	"sort"
+)
+
+// drawSliders renders each slider's level as a vertical bar onto canvas, one
+// bar per slider left to right in ascending slider ID order. It's shared by
+// every pixel-addressable driver (SSD1306, the mock PNG renderer) so the
+// layout stays consistent across them; HD44780 renders text instead and
+// doesn't use this. labels is accepted but not drawn.
+func drawSliders(canvas draw.Image, labels map[int]string, levels map[int]float32) {
+	bounds := canvas.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	ids := make([]int, 0, len(levels))
+	for id := range levels {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	if len(ids) == 0 {
+		return
+	}
+
+	barWidth := width / len(ids)
+
+	for i, id := range ids {
+		barHeight := int(float32(height) * levels[id])
+		x0 := bounds.Min.X + i*barWidth
+
+		for y := bounds.Min.Y + height - barHeight; y < bounds.Min.Y+height; y++ {
+			for x := x0; x < x0+barWidth-1; x++ {
+				canvas.Set(x, y, color.White)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+//go:build linux
+
+package display
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/devices/v3/ssd1306"
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+	"periph.io/x/host/v3"
+)
+
+func init() {
+	register("ssd1306", newSSD1306Display)
+}
+
+// ssd1306Display renders slider level bars to an SSD1306 OLED over I2C.
+// Slider labels are stored but not drawn. It uses Options.Bus, Width and
+// Height; Options.Address is not passed to the device.
+type ssd1306Display struct {
+	logger *zap.SugaredLogger
+
+	dev *ssd1306.Dev
+	img *image1bit.VerticalLSB
+
+	mutex  sync.Mutex
+	labels map[int]string
+	levels map[int]float32
+}
+
+func newSSD1306Display(logger *zap.SugaredLogger, options Options) (Display, error) {
+	logger = logger.Named("display.ssd1306")
+
+	if _, err := host.Init(); err != nil {
+		return nil, fmt.Errorf("init periph host: %w", err)
+	}
+
+	bus, err := i2creg.Open(options.Bus)
+	if err != nil {
+		return nil, fmt.Errorf("open i2c bus %q: %w", options.Bus, err)
+	}
+
+	dev, err := ssd1306.NewI2C(bus, &ssd1306.Opts{
+		W:       options.Width,
+		H:       options.Height,
+		Rotated: false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("init ssd1306 device: %w", err)
+	}
+
+	return &ssd1306Display{
+		logger: logger,
+		dev:    dev,
+		img:    image1bit.NewVerticalLSB(dev.Bounds()),
+		labels: make(map[int]string),
+		levels: make(map[int]float32),
+	}, nil
+}
+
+func (d *ssd1306Display) SetSliderLabel(sliderID int, label string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.labels[sliderID] = label
+
+	return d.render()
+}
+
+func (d *ssd1306Display) SetSliderLevel(sliderID int, level float32) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.levels[sliderID] = level
+
+	return d.render()
+}
+
+// ShowToast only logs message at debug level; nothing is drawn on the screen.
+func (d *ssd1306Display) ShowToast(message string) error {
+	d.logger.Debugw("Toast", "message", message)
+	return nil
+}
+
+func (d *ssd1306Display) Clear() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.labels = make(map[int]string)
+	d.levels = make(map[int]float32)
+
+	return d.render()
+}
+
+// render draws every known slider's bar into a blank frame buffer and
+// flushes it to the device. It must be called with d.mutex held.
+func (d *ssd1306Display) render() error {
+	d.img = image1bit.NewVerticalLSB(d.dev.Bounds())
+	drawSliders(d.img, d.labels, d.levels)
+
+	if err := d.dev.Draw(d.dev.Bounds(), d.img, d.img.Bounds().Min); err != nil {
+		return fmt.Errorf("draw to ssd1306: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package deej
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/omriharel/deej/backend"
+)
+
+// sessionBinding is a single slider's resolved session on one backend.
+type sessionBinding struct {
+	backendName string
+	session     backend.Session
+}
+
+// sessionMapper resolves the slider->session names in config.SliderMapping
+// against whichever SessionBackends are active, and is the only thing that
+// actually calls SetVolume/SetMute/Bind/Subscribe on a backend. Everything
+// else (HTTP API, UAPI socket, display, tray) goes through it rather than
+// touching a backend directly.
+type sessionMapper struct {
+	logger *zap.SugaredLogger
+	deej   *Deej
+
+	mutex    sync.Mutex
+	bindings map[int][]sessionBinding
+}
+
+// newSessionMapper creates a sessionMapper for the given Deej instance. Call
+// rebind once backends are initialized to perform the first resolution.
+func newSessionMapper(logger *zap.SugaredLogger, d *Deej) *sessionMapper {
+	return &sessionMapper{
+		logger:   logger.Named("session_mapper"),
+		deej:     d,
+		bindings: make(map[int][]sessionBinding),
+	}
+}
+
+// rebind re-resolves every slider's configured session names against the
+// active backends. It's called once at startup and again whenever
+// SliderMappingChanged fires, so a config edit takes effect without
+// restarting anything else.
+func (sm *sessionMapper) rebind(mapping *sliderMap) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.bindings = make(map[int][]sessionBinding)
+
+	if mapping == nil {
+		return
+	}
+
+	mapping.iterate(func(sliderID int, targetNames []string) {
+		for _, name := range targetNames {
+			bound := false
+
+			for backendName, sb := range sm.deej.backends {
+				session, err := sb.Bind(name)
+				if err != nil {
+					continue
+				}
+
+				sm.bindings[sliderID] = append(sm.bindings[sliderID], sessionBinding{
+					backendName: backendName,
+					session:     session,
+				})
+				bound = true
+			}
+
+			if !bound {
+				sm.logger.Warnw("No backend could bind session", "slider", sliderID, "session", name)
+			}
+		}
+	})
+
+	sm.publishInitialState()
+}
+
+// publishInitialState pushes each slider's bound session names (but no
+// volume reading yet - that comes from the physical slider or a backend
+// event) into Deej's published state, so a newly (re)bound slider shows its
+// label immediately instead of waiting for the first volume change.
+func (sm *sessionMapper) publishInitialState() {
+	for sliderID, bindings := range sm.bindings {
+		names := make([]string, 0, len(bindings))
+		for _, b := range bindings {
+			names = append(names, b.session.DisplayName)
+		}
+
+		sm.deej.updateSliderSessions(sliderID, names)
+	}
+}
+
+// boundBackends returns the bindings for sliderID, optionally filtered down
+// to just the one matching sessionName. An empty sessionName means "every
+// session bound to this slider".
+func (sm *sessionMapper) boundBackends(sliderID int, sessionName string) []sessionBinding {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	bindings := sm.bindings[sliderID]
+
+	if sessionName == "" {
+		return bindings
+	}
+
+	matched := make([]sessionBinding, 0, len(bindings))
+	for _, b := range bindings {
+		if b.session.DisplayName == sessionName || b.session.Key == sessionName {
+			matched = append(matched, b)
+		}
+	}
+
+	return matched
+}
+
+// setVolume pins sessionName's volume (or every session bound to sliderID,
+// if sessionName is empty) across every backend it resolved to.
+func (sm *sessionMapper) setVolume(sliderID int, sessionName string, volume float32) error {
+	bindings := sm.boundBackends(sliderID, sessionName)
+	if len(bindings) == 0 {
+		return fmt.Errorf("slider %d has no bound session matching %q", sliderID, sessionName)
+	}
+
+	var firstErr error
+	for _, b := range bindings {
+		if err := sm.deej.backends[b.backendName].SetVolume(b.session, volume); err != nil {
+			sm.logger.Warnw("Failed to set volume", "slider", sliderID, "backend", b.backendName, "error", err)
+
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// setMute mutes or unmutes sessionName (or every session bound to sliderID,
+// if sessionName is empty) across every backend it resolved to.
+func (sm *sessionMapper) setMute(sliderID int, sessionName string, muted bool) error {
+	bindings := sm.boundBackends(sliderID, sessionName)
+	if len(bindings) == 0 {
+		return fmt.Errorf("slider %d has no bound session matching %q", sliderID, sessionName)
+	}
+
+	var firstErr error
+	for _, b := range bindings {
+		if err := sm.deej.backends[b.backendName].SetMute(b.session, muted); err != nil {
+			sm.logger.Warnw("Failed to set mute", "slider", sliderID, "backend", b.backendName, "error", err)
+
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// watchBackend subscribes to a backend's own change events (a session's
+// volume or mute changing outside of deej, e.g. from the app itself) and
+// reflects them back into Deej's published slider state. It blocks until the
+// backend closes its event channel on Shutdown, so it's meant to run in its
+// own goroutine.
+func (sm *sessionMapper) watchBackend(name string, sb backend.SessionBackend) {
+	events, err := sb.Subscribe()
+	if err != nil {
+		sm.logger.Warnw("Failed to subscribe to backend events", "backend", name, "error", err)
+		return
+	}
+
+	for event := range events {
+		sliderID, ok := sm.sliderForSession(name, event.Session)
+		if !ok {
+			continue
+		}
+
+		sm.deej.updateSliderVolume(sliderID, event.Volume)
+	}
+}
+
+// sliderForSession reverse-looks-up which slider (if any) has event.Session
+// bound to it on the given backend.
+func (sm *sessionMapper) sliderForSession(backendName string, session backend.Session) (int, bool) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	for sliderID, bindings := range sm.bindings {
+		for _, b := range bindings {
+			if b.backendName == backendName && b.session.Key == session.Key {
+				return sliderID, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,24 @@
+//go:build linux || darwin
+
+package uapi
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// Listen opens the control socket at the given Unix domain socket path,
+// removing a stale socket file left behind by an unclean shutdown first.
+func Listen(socketPath string) (net.Listener, error) {
+	if _, err := os.Stat(socketPath); err == nil {
+		os.Remove(socketPath)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("listen on unix socket %q: %w", socketPath, err)
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package uapi
+
+import (
+	"fmt"
+	"net"
+
+	"golang.zx2c4.com/wireguard/ipc/namedpipe"
+)
+
+// Listen opens the control named pipe, e.g. \\.\pipe\deej.
+func Listen(pipeName string) (net.Listener, error) {
+	listener, err := namedpipe.Listen(pipeName)
+	if err != nil {
+		return nil, fmt.Errorf("listen on named pipe %q: %w", pipeName, err)
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,214 @@
+// Package uapi implements a wireguard-go-style control protocol for deej: a
+// line-oriented command/response protocol served over a Unix domain socket
+// (Linux/macOS) or a named pipe (Windows). It lets external tools query and
+// drive slider state with a shell one-liner instead of standing up an HTTP
+// client, e.g. for keybind scripts.
+//
+// Supported commands, one per line:
+//
+//	get=1                             list every slider's current state
+//	set slider N volume 0.42          pin slider N's volume override
+//	set slider N mute 1               mute (or 0 to unmute) slider N
+//	reload                            force a config reload
+//	subscribe events                  stream slider changes until disconnect
+//
+// Every response is a block of `key=value` lines terminated by a blank line,
+// ending with `errno=0` on success or `errno=1` plus an `error=` line on failure.
+package uapi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/omriharel/deej/api"
+)
+
+// Server serves the UAPI control protocol against a StateProvider. It's
+// intentionally built on api.StateProvider rather than its own interface, so
+// deej only has to implement one control surface to back both the HTTP API
+// and this socket.
+type Server struct {
+	logger *zap.SugaredLogger
+	state  api.StateProvider
+
+	listener net.Listener
+
+	subscribersMutex sync.Mutex
+	subscribers      map[chan api.SliderState]bool
+}
+
+// NewServer creates a UAPI server bound to the given state provider. Call
+// Serve with a listener (see Listen) to start accepting connections.
+func NewServer(logger *zap.SugaredLogger, state api.StateProvider) *Server {
+	return &Server{
+		logger:      logger.Named("uapi"),
+		state:       state,
+		subscribers: make(map[chan api.SliderState]bool),
+	}
+}
+
+// Serve accepts connections from listener until it's closed (by Stop),
+// handling each one in its own goroutine.
+func (s *Server) Serve(listener net.Listener) error {
+	s.listener = listener
+	s.logger.Infow("Serving control socket", "address", listener.Addr())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return fmt.Errorf("accept control connection: %w", err)
+		}
+
+		go s.handleConn(conn)
+	}
+}
+
+// Stop closes the listener and every open "subscribe events" stream.
+func (s *Server) Stop() {
+	if s.listener != nil {
+		s.listener.Close()
+	}
+
+	s.subscribersMutex.Lock()
+	for ch := range s.subscribers {
+		close(ch)
+		delete(s.subscribers, ch)
+	}
+	s.subscribersMutex.Unlock()
+}
+
+// PublishSliderEvent fans a slider update out to every client currently
+// streaming "subscribe events".
+func (s *Server) PublishSliderEvent(state api.SliderState) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- state:
+		default:
+			// slow consumer, drop the event rather than block the publisher
+		}
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.EqualFold(line, "subscribe events") {
+			s.streamEvents(conn)
+			return
+		}
+
+		s.handleCommand(conn, line)
+	}
+}
+
+func (s *Server) handleCommand(w io.Writer, line string) {
+	fields := strings.Fields(line)
+
+	switch fields[0] {
+	case "get=1":
+		s.writeSliders(w)
+	case "reload":
+		if err := s.state.ReloadConfig(); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeOK(w)
+	case "set":
+		s.handleSet(w, fields[1:])
+	default:
+		writeError(w, fmt.Errorf("unknown command %q", fields[0]))
+	}
+}
+
+func (s *Server) handleSet(w io.Writer, args []string) {
+	if len(args) < 4 || args[0] != "slider" {
+		writeError(w, fmt.Errorf("usage: set slider <id> volume <0-1> | set slider <id> mute <0|1>"))
+		return
+	}
+
+	sliderID, err := strconv.Atoi(args[1])
+	if err != nil {
+		writeError(w, fmt.Errorf("invalid slider id %q", args[1]))
+		return
+	}
+
+	switch args[2] {
+	case "volume":
+		volume, err := strconv.ParseFloat(args[3], 32)
+		if err != nil {
+			writeError(w, fmt.Errorf("invalid volume %q", args[3]))
+			return
+		}
+
+		if err := s.state.SetSliderOverride(sliderID, "", float32(volume)); err != nil {
+			writeError(w, err)
+			return
+		}
+	case "mute":
+		if err := s.state.SetSliderMute(sliderID, "", args[3] == "1"); err != nil {
+			writeError(w, err)
+			return
+		}
+	default:
+		writeError(w, fmt.Errorf("unknown set target %q", args[2]))
+		return
+	}
+
+	writeOK(w)
+}
+
+func (s *Server) writeSliders(w io.Writer) {
+	for _, slider := range s.state.Sliders() {
+		fmt.Fprintf(w, "slider=%d\nvolume=%.4f\n", slider.SliderID, slider.Value)
+	}
+
+	writeOK(w)
+}
+
+func (s *Server) streamEvents(w io.Writer) {
+	ch := make(chan api.SliderState, 16)
+
+	s.subscribersMutex.Lock()
+	s.subscribers[ch] = true
+	s.subscribersMutex.Unlock()
+
+	defer func() {
+		s.subscribersMutex.Lock()
+		delete(s.subscribers, ch)
+		s.subscribersMutex.Unlock()
+	}()
+
+	for state := range ch {
+		fmt.Fprintf(w, "slider=%d\nvolume=%.4f\n\n", state.SliderID, state.Value)
+	}
+}
+
+func writeOK(w io.Writer) {
+	fmt.Fprintln(w, "errno=0")
+	fmt.Fprintln(w)
+}
+
+func writeError(w io.Writer, err error) {
+	fmt.Fprintln(w, "errno=1")
+	fmt.Fprintf(w, "error=%s\n", err)
+	fmt.Fprintln(w)
+}